@@ -2,6 +2,7 @@ package cadence
 
 import (
 	"context"
+	"reflect"
 	"time"
 
 	"github.com/uber-go/cadence-client/.gen/go/shared"
@@ -20,6 +21,33 @@ type (
 		WorkflowExecution WorkflowExecution
 		ActivityID        string
 		ActivityType      ActivityType
+		Attempt           int32 // Attempt starts from 0 and increased by 1 for every retry if retry policy is specified.
+	}
+
+	// RetryPolicy defines the retry policy that can be attached to an activity through ActivityOptions.
+	// Cadence server will retry the activity according to this policy when it fails with a retryable error.
+	RetryPolicy struct {
+		// InitialInterval is the backoff interval for the first retry. Required, must be positive.
+		InitialInterval time.Duration
+
+		// BackoffCoefficient is used to calculate the next backoff interval. The next interval is
+		// previous interval multiplied by this coefficient. Must be 1 or larger.
+		BackoffCoefficient float64
+
+		// MaximumInterval is the maximum backoff interval between retries. Optional, default is
+		// 100x of InitialInterval.
+		MaximumInterval time.Duration
+
+		// MaximumAttempts is the maximum number of attempts. It includes the original attempt. Required,
+		// must be positive. When both MaximumAttempts and ExpirationInterval are specified, retries stop
+		// when either condition is met.
+		MaximumAttempts int32
+
+		// ExpirationInterval is the maximum amount of time to retry, starting from the first attempt.
+		ExpirationInterval time.Duration
+
+		// NonRetryableErrorReasons is a list of error reasons that are not retryable.
+		NonRetryableErrorReasons []string
 	}
 )
 
@@ -50,6 +78,7 @@ func GetActivityInfo(ctx context.Context) ActivityInfo {
 		ActivityType:      env.activityType,
 		TaskToken:         env.taskToken,
 		WorkflowExecution: env.workflowExecution,
+		Attempt:           env.attempt,
 	}
 }
 
@@ -64,19 +93,105 @@ func GetActivityLogger(ctx context.Context) *zap.Logger {
 // the context with error context.Canceled.
 // 	TODO: we don't have a way to distinguish between the two cases when context is cancelled because
 // 	context doesn't support overriding value of ctx.Error.
-// 	TODO: Implement automatic heartbeating with cancellation through ctx.
 // details - the details that you provided here can be seen in the worflow when it receives TimeoutError, you
 //	can check error TimeOutType()/Details().
 func RecordActivityHeartbeat(ctx context.Context, details ...interface{}) {
-	data, err := getHostEnvironment().encodeArgs(details)
+	env := getActivityEnv(ctx)
+	dataConverter := env.dataConverter
+	if dataConverter == nil {
+		dataConverter = getDefaultDataConverter()
+	}
+	data, err := dataConverter.ToData(details...)
 	if err != nil {
 		panic(err)
 	}
-	env := getActivityEnv(ctx)
 	err = env.serviceInvoker.Heartbeat(data)
 	if err != nil {
 		log := GetActivityLogger(ctx)
 		log.Debug("RecordActivityHeartbeat With Error:", zap.Error(err))
+		cancelOnHeartbeatError(env, err)
+	}
+}
+
+// WithAutoHeartbeat spawns a goroutine, tied to the lifetime of the activity context, that calls
+// RecordActivityHeartbeat every interval without the activity implementation having to do so itself. If
+// interval is zero it defaults to half of the activity's configured HeartbeatTimeout. details is invoked
+// before each heartbeat to produce the details payload; when it returns the same value (compared with
+// reflect.DeepEqual) as the previous heartbeat, the heartbeat is skipped to avoid redundant service calls.
+// If the service reports that the activity was cancelled or no longer exists, the context returned by
+// WithAutoHeartbeat is cancelled with context.Canceled so the activity can unwind through ctx.Done(). The
+// same cancellation happens if the worker is shutting down.
+func WithAutoHeartbeat(ctx context.Context, interval time.Duration, details func() interface{}) context.Context {
+	env := getActivityEnv(ctx)
+	if interval <= 0 {
+		interval = time.Duration(env.heartbeatTimeoutSeconds) * time.Second / 2
+	}
+	if interval <= 0 {
+		env.logger.Error("WithAutoHeartbeat: no interval given and no HeartbeatTimeout configured on the activity; not starting automatic heartbeating")
+		return ctx
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	env.cancelHandler = cancel
+	go autoHeartbeat(ctx, env, interval, details)
+	return ctx
+}
+
+func autoHeartbeat(ctx context.Context, env *activityEnvironment, interval time.Duration, details func() interface{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastDetails interface{}
+	haveLastDetails := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-env.workerStopChannel:
+			cancelOnWorkerShutdown(env)
+			return
+		case <-ticker.C:
+			var d interface{}
+			if details != nil {
+				d = details()
+			}
+			if haveLastDetails && reflect.DeepEqual(d, lastDetails) {
+				continue
+			}
+			lastDetails, haveLastDetails = d, true
+
+			dataConverter := env.dataConverter
+			if dataConverter == nil {
+				dataConverter = getDefaultDataConverter()
+			}
+			data, err := dataConverter.ToData(d)
+			if err != nil {
+				continue
+			}
+			if err := env.serviceInvoker.Heartbeat(data); err != nil {
+				cancelOnHeartbeatError(env, err)
+			}
+		}
+	}
+}
+
+// cancelOnHeartbeatError cancels the activity context when the service reports that the activity was
+// cancelled, or that the workflow/activity no longer exists, so callers don't keep doing work the service
+// has already given up on.
+func cancelOnHeartbeatError(env *activityEnvironment, err error) {
+	if env.cancelHandler == nil {
+		return
+	}
+	switch err.(type) {
+	case *ActivityTaskCanceledError, *EntityNotExistsError:
+		env.cancelHandler()
+	}
+}
+
+// cancelOnWorkerShutdown cancels the activity context when the worker hosting it is shutting down, so the
+// activity can unwind through ctx.Done() instead of being killed mid-flight.
+func cancelOnWorkerShutdown(env *activityEnvironment) {
+	if env.cancelHandler != nil {
+		env.cancelHandler()
 	}
 }
 
@@ -89,11 +204,15 @@ type ServiceInvoker interface {
 
 // WithActivityTask adds activity specific information into context.
 // Use this method to unit test activity implementations that use context extractor methodshared.
+// workerStopChannel, if non-nil, is closed when the worker hosting this activity is shutting down; a context
+// returned from WithAutoHeartbeat is cancelled when that happens.
 func WithActivityTask(
 	ctx context.Context,
 	task *shared.PollForActivityTaskResponse,
 	invoker ServiceInvoker,
 	logger *zap.Logger,
+	dataConverter DataConverter,
+	workerStopChannel <-chan struct{},
 ) context.Context {
 	// TODO: Add activity start to close timeout to activity task and use it as the deadline
 	return context.WithValue(ctx, activityEnvContextKey, &activityEnvironment{
@@ -104,10 +223,22 @@ func WithActivityTask(
 		workflowExecution: WorkflowExecution{
 			RunID: *task.WorkflowExecution.RunId,
 			ID:    *task.WorkflowExecution.WorkflowId},
-		logger: logger,
+		logger:                  logger,
+		attempt:                 task.GetAttempt(),
+		dataConverter:           dataConverter,
+		heartbeatTimeoutSeconds: task.GetHeartbeatTimeoutSeconds(),
+		workerStopChannel:       workerStopChannel,
+		header:                  task.GetHeader().GetFields(),
 	})
 }
 
+// GetActivityHeader returns the header carried on the activity task that started the currently executing
+// activity, such as a propagated OpenTracing span context. Empty if the caller that scheduled the activity
+// didn't set one.
+func GetActivityHeader(ctx context.Context) map[string][]byte {
+	return getActivityEnv(ctx).header
+}
+
 // ActivityOptions stores all activity-specific parameters that will
 // be stored inside of a context.
 type ActivityOptions interface {
@@ -117,6 +248,8 @@ type ActivityOptions interface {
 	WithStartToCloseTimeout(d time.Duration) ActivityOptions
 	WithHeartbeatTimeout(d time.Duration) ActivityOptions
 	WithWaitForCancellation(wait bool) ActivityOptions
+	WithRetryPolicy(retryPolicy *RetryPolicy) ActivityOptions
+	WithDataConverter(dataConverter DataConverter) ActivityOptions
 }
 
 // NewActivityOptions returns an instance of activity options that can be used to specify
@@ -156,6 +289,12 @@ func WithActivityOptions(ctx Context, options ActivityOptions) Context {
 	if ao.activityID != nil {
 		eap.ActivityID = ao.activityID
 	}
+	if ao.retryPolicy != nil {
+		eap.RetryPolicy = ao.retryPolicy
+	}
+	if ao.dataConverter != nil {
+		ctx1 = WithDataConverter(ctx1, ao.dataConverter)
+	}
 	return ctx1
 }
 
@@ -199,4 +338,11 @@ func WithWaitForCancellation(ctx Context, wait bool) Context {
 	ctx1 := setActivityParametersIfNotExist(ctx)
 	getActivityOptions(ctx1).WaitForCancellation = wait
 	return ctx1
+}
+
+// WithRetryPolicy adds a retry policy to the context that will be used for scheduling the activity.
+func WithRetryPolicy(ctx Context, retryPolicy RetryPolicy) Context {
+	ctx1 := setActivityParametersIfNotExist(ctx)
+	getActivityOptions(ctx1).RetryPolicy = &retryPolicy
+	return ctx1
 }
\ No newline at end of file
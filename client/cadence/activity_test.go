@@ -0,0 +1,93 @@
+package cadence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type fakeHeartbeatInvoker struct {
+	err error
+}
+
+func (f *fakeHeartbeatInvoker) Heartbeat(details []byte) error {
+	return f.err
+}
+
+func newTestActivityEnv() *activityEnvironment {
+	return &activityEnvironment{
+		serviceInvoker: &fakeHeartbeatInvoker{},
+		logger:         zap.NewNop(),
+	}
+}
+
+func TestWithAutoHeartbeatZeroIntervalDoesNotStartTicking(t *testing.T) {
+	env := newTestActivityEnv() // heartbeatTimeoutSeconds is 0, so no interval can be derived
+	ctx := context.WithValue(context.Background(), activityEnvContextKey, env)
+
+	result := WithAutoHeartbeat(ctx, 0, nil)
+
+	if result != ctx {
+		t.Fatal("expected WithAutoHeartbeat to return ctx unchanged when no interval is available")
+	}
+	if env.cancelHandler != nil {
+		t.Fatal("expected no cancelHandler to be installed when automatic heartbeating did not start")
+	}
+}
+
+func TestWithAutoHeartbeatTicksAndStopsOnCancel(t *testing.T) {
+	env := newTestActivityEnv()
+	ctx := context.WithValue(context.Background(), activityEnvContextKey, env)
+
+	ticks := make(chan struct{}, 10)
+	ctx = WithAutoHeartbeat(ctx, 5*time.Millisecond, func() interface{} {
+		ticks <- struct{}{}
+		return nil
+	})
+
+	select {
+	case <-ticks:
+	case <-time.After(time.Second):
+		t.Fatal("expected at least one heartbeat tick")
+	}
+
+	env.cancelHandler()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected ctx to be cancelled")
+	}
+}
+
+func TestWithAutoHeartbeatCancelsOnHeartbeatError(t *testing.T) {
+	env := newTestActivityEnv()
+	env.serviceInvoker = &fakeHeartbeatInvoker{err: &ActivityTaskCanceledError{}}
+	ctx := context.WithValue(context.Background(), activityEnvContextKey, env)
+
+	ctx = WithAutoHeartbeat(ctx, 5*time.Millisecond, nil)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected ctx to be cancelled after a cancellation error from the service")
+	}
+}
+
+func TestWithAutoHeartbeatCancelsOnWorkerShutdown(t *testing.T) {
+	env := newTestActivityEnv()
+	stopCh := make(chan struct{})
+	env.workerStopChannel = stopCh
+	ctx := context.WithValue(context.Background(), activityEnvContextKey, env)
+
+	ctx = WithAutoHeartbeat(ctx, 5*time.Millisecond, nil)
+	close(stopCh)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected ctx to be cancelled when the worker shuts down")
+	}
+}
@@ -0,0 +1,151 @@
+package cadence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/uber-go/cadence-client/.gen/go/cadence/workflowserviceclient"
+	"github.com/uber-go/cadence-client/.gen/go/shared"
+	"github.com/uber-go/cadence-client/common"
+)
+
+type (
+	// Client is used to perform client-side operations against the Cadence service that fall outside of a
+	// running workflow or activity, such as completing an activity asynchronously from outside the goroutine
+	// that originally received the activity task.
+	Client interface {
+		// CompleteActivity reports completion of an activity that was started with the given task token. Use
+		// this when an activity function returned ErrResultPending to signal that it will be completed later,
+		// out-of-band, by whatever process eventually produces the result. Pass a non-nil err to fail the
+		// activity instead of completing it successfully; pass a *CanceledError to report cancellation.
+		CompleteActivity(ctx context.Context, taskToken []byte, result interface{}, err error) error
+
+		// CompleteActivityByID completes an activity addressed by domain/workflowID/runID/activityID instead of
+		// a task token. Useful when the task token was not persisted alongside the application's own state for
+		// the pending activity.
+		CompleteActivityByID(ctx context.Context, domain, workflowID, runID, activityID string, result interface{}, err error) error
+	}
+
+	workflowClient struct {
+		workflowService workflowserviceclient.Interface
+		domain          string
+		dataConverter   DataConverter
+	}
+
+	// CanceledError is a typed error used to report that an async activity was cancelled rather than failed.
+	// Pass an instance of it as the err argument to Client.CompleteActivity/CompleteActivityByID.
+	CanceledError struct {
+		details []interface{}
+	}
+)
+
+// ErrResultPending is returned from an activity's Execute function to indicate that the activity is not
+// completing synchronously; it has handed its task token (or activity ID) to an external system and will be
+// completed later through Client.CompleteActivity or Client.CompleteActivityByID. The worker recognizes this
+// sentinel in validateFunctionAndGetResults and leaves the activity in the Started state on the service rather
+// than reporting completion.
+var ErrResultPending = errors.New("not error: do not autocomplete, this is from an activity that has not completed yet")
+
+const clientIdentity = "cadence-client"
+
+// NewCanceledError creates a CanceledError carrying optional details to surface to whatever observes the
+// activity's cancellation.
+func NewCanceledError(details ...interface{}) *CanceledError {
+	return &CanceledError{details: details}
+}
+
+func (e *CanceledError) Error() string {
+	return "activity canceled"
+}
+
+// NewClient creates an instance of a Client that talks to the Cadence service identified by workflowService.
+func NewClient(workflowService workflowserviceclient.Interface, domain string, dataConverter DataConverter) Client {
+	if dataConverter == nil {
+		dataConverter = getDefaultDataConverter()
+	}
+	return &workflowClient{
+		workflowService: workflowService,
+		domain:          domain,
+		dataConverter:   dataConverter,
+	}
+}
+
+func (wc *workflowClient) CompleteActivity(ctx context.Context, taskToken []byte, result interface{}, activityErr error) error {
+	if activityErr == ErrResultPending {
+		return errors.New("cannot complete an activity with ErrResultPending, it is not a real result")
+	}
+	if canceledErr, ok := activityErr.(*CanceledError); ok {
+		details, err := wc.dataConverter.ToData(canceledErr.details...)
+		if err != nil {
+			return err
+		}
+		return wc.workflowService.RespondActivityTaskCanceled(ctx, &shared.RespondActivityTaskCanceledRequest{
+			TaskToken: taskToken,
+			Details:   details,
+			Identity:  common.StringPtr(clientIdentity),
+		})
+	}
+	if activityErr != nil {
+		return wc.workflowService.RespondActivityTaskFailed(ctx, &shared.RespondActivityTaskFailedRequest{
+			TaskToken: taskToken,
+			Reason:    common.StringPtr(activityErr.Error()),
+			Identity:  common.StringPtr(clientIdentity),
+		})
+	}
+	data, err := wc.dataConverter.ToData(result)
+	if err != nil {
+		return err
+	}
+	return wc.workflowService.RespondActivityTaskCompleted(ctx, &shared.RespondActivityTaskCompletedRequest{
+		TaskToken: taskToken,
+		Result:    data,
+		Identity:  common.StringPtr(clientIdentity),
+	})
+}
+
+func (wc *workflowClient) CompleteActivityByID(
+	ctx context.Context,
+	domain, workflowID, runID, activityID string,
+	result interface{},
+	activityErr error,
+) error {
+	if activityErr == ErrResultPending {
+		return errors.New("cannot complete an activity with ErrResultPending, it is not a real result")
+	}
+	if canceledErr, ok := activityErr.(*CanceledError); ok {
+		details, err := wc.dataConverter.ToData(canceledErr.details...)
+		if err != nil {
+			return err
+		}
+		return wc.workflowService.RespondActivityTaskCanceledByID(ctx, &shared.RespondActivityTaskCanceledByIDRequest{
+			Domain:     common.StringPtr(domain),
+			WorkflowID: common.StringPtr(workflowID),
+			RunID:      common.StringPtr(runID),
+			ActivityID: common.StringPtr(activityID),
+			Details:    details,
+			Identity:   common.StringPtr(clientIdentity),
+		})
+	}
+	if activityErr != nil {
+		return wc.workflowService.RespondActivityTaskFailedByID(ctx, &shared.RespondActivityTaskFailedByIDRequest{
+			Domain:     common.StringPtr(domain),
+			WorkflowID: common.StringPtr(workflowID),
+			RunID:      common.StringPtr(runID),
+			ActivityID: common.StringPtr(activityID),
+			Reason:     common.StringPtr(activityErr.Error()),
+			Identity:   common.StringPtr(clientIdentity),
+		})
+	}
+	data, err := wc.dataConverter.ToData(result)
+	if err != nil {
+		return err
+	}
+	return wc.workflowService.RespondActivityTaskCompletedByID(ctx, &shared.RespondActivityTaskCompletedByIDRequest{
+		Domain:     common.StringPtr(domain),
+		WorkflowID: common.StringPtr(workflowID),
+		RunID:      common.StringPtr(runID),
+		ActivityID: common.StringPtr(activityID),
+		Result:     data,
+		Identity:   common.StringPtr(clientIdentity),
+	})
+}
@@ -0,0 +1,95 @@
+package cadence
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type (
+	// DataConverter is used by the framework to serialize/deserialize activity and workflow parameters and
+	// results that need to cross the wire. Register a custom implementation through WorkerOptions.DataConverter
+	// to apply it to every activity run by that worker, or scope it to a single call tree with WithDataConverter.
+	// This unlocks encryption, compression, or cross-language payloads without changing activity code.
+	DataConverter interface {
+		// ToData implements conversion of a list of values.
+		ToData(value ...interface{}) ([]byte, error)
+		// FromData implements conversion of an array of bytes to a list of values.
+		FromData(input []byte, valuePtr ...interface{}) error
+	}
+
+	// defaultDataConverter is the JSON based DataConverter, matching the wire format used before DataConverter
+	// was introduced.
+	defaultDataConverter struct {
+	}
+
+	// protoDataConverter serializes values using protocol buffers. Every value passed to ToData/FromData must
+	// implement proto.Message.
+	protoDataConverter struct {
+	}
+)
+
+const dataConverterContextKey = "dataConverter"
+
+// getDefaultDataConverter returns the default, JSON based DataConverter.
+func getDefaultDataConverter() DataConverter {
+	return &defaultDataConverter{}
+}
+
+// NewProtoDataConverter returns a DataConverter that serializes each value using protocol buffers.
+func NewProtoDataConverter() DataConverter {
+	return &protoDataConverter{}
+}
+
+// WithDataConverter adds a DataConverter to the context that activities scheduled from it will use to
+// serialize their input and deserialize their result, instead of the default JSON converter.
+func WithDataConverter(ctx Context, dataConverter DataConverter) Context {
+	return WithValue(ctx, dataConverterContextKey, dataConverter)
+}
+
+// getDataConverterFromContext returns the DataConverter set on ctx via WithDataConverter, falling back to
+// the default JSON converter if none was set.
+func getDataConverterFromContext(ctx Context) DataConverter {
+	dc := ctx.Value(dataConverterContextKey)
+	if dc == nil {
+		return getDefaultDataConverter()
+	}
+	return dc.(DataConverter)
+}
+
+func (dc *defaultDataConverter) ToData(r ...interface{}) ([]byte, error) {
+	if len(r) == 1 {
+		return json.Marshal(r[0])
+	}
+	return json.Marshal(r)
+}
+
+func (dc *defaultDataConverter) FromData(data []byte, to ...interface{}) error {
+	if len(to) == 1 {
+		return json.Unmarshal(data, to[0])
+	}
+	return json.Unmarshal(data, &to)
+}
+
+func (pc *protoDataConverter) ToData(r ...interface{}) ([]byte, error) {
+	if len(r) != 1 {
+		return nil, fmt.Errorf("proto data converter only supports a single value, got %d", len(r))
+	}
+	msg, ok := r[0].(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("proto data converter requires a proto.Message, got %T", r[0])
+	}
+	return proto.Marshal(msg)
+}
+
+func (pc *protoDataConverter) FromData(data []byte, to ...interface{}) error {
+	if len(to) != 1 {
+		return fmt.Errorf("proto data converter only supports a single value, got %d", len(to))
+	}
+	msg, ok := to[0].(proto.Message)
+	if !ok {
+		return fmt.Errorf("proto data converter requires a proto.Message, got %T", to[0])
+	}
+	return proto.Unmarshal(data, msg)
+}
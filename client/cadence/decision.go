@@ -0,0 +1,44 @@
+package cadence
+
+// All code in this file is private to the package.
+
+import (
+	"github.com/uber-go/cadence-client/.gen/go/shared"
+	"github.com/uber-go/cadence-client/common"
+)
+
+// newScheduleActivityTaskAttributes translates executeActivityParameters into the
+// ScheduleActivityTaskDecisionAttributes the service expects when a decision task handler emits a
+// ScheduleActivityTask decision, including the activity's RetryPolicy and header. This is the one place
+// RetryPolicy/Header are turned into their wire representation; the decision task handler that appends this
+// decision to the outgoing RespondDecisionTaskCompletedRequest lives alongside the rest of the workflow
+// execution engine.
+func newScheduleActivityTaskAttributes(parameters executeActivityParameters) *shared.ScheduleActivityTaskDecisionAttributes {
+	attributes := &shared.ScheduleActivityTaskDecisionAttributes{
+		ActivityId:                    parameters.ActivityID,
+		ActivityType:                  &shared.ActivityType{Name: common.StringPtr(parameters.ActivityType.Name)},
+		TaskList:                      &shared.TaskList{Name: common.StringPtr(parameters.TaskListName)},
+		Input:                         parameters.Input,
+		ScheduleToCloseTimeoutSeconds: common.Int32Ptr(parameters.ScheduleToCloseTimeoutSeconds),
+		ScheduleToStartTimeoutSeconds: common.Int32Ptr(parameters.ScheduleToStartTimeoutSeconds),
+		StartToCloseTimeoutSeconds:    common.Int32Ptr(parameters.StartToCloseTimeoutSeconds),
+		HeartbeatTimeoutSeconds:       common.Int32Ptr(parameters.HeartbeatTimeoutSeconds),
+	}
+
+	if len(parameters.Header) > 0 {
+		attributes.Header = &shared.Header{Fields: parameters.Header}
+	}
+
+	if rp := parameters.RetryPolicy; rp != nil {
+		attributes.RetryPolicy = &shared.RetryPolicy{
+			InitialIntervalInSeconds:    common.Int32Ptr(int32(rp.InitialInterval.Seconds())),
+			BackoffCoefficient:          common.Float64Ptr(rp.BackoffCoefficient),
+			MaximumIntervalInSeconds:    common.Int32Ptr(int32(rp.MaximumInterval.Seconds())),
+			MaximumAttempts:             common.Int32Ptr(rp.MaximumAttempts),
+			ExpirationIntervalInSeconds: common.Int32Ptr(int32(rp.ExpirationInterval.Seconds())),
+			NonRetryableErrorReasons:    rp.NonRetryableErrorReasons,
+		}
+	}
+
+	return attributes
+}
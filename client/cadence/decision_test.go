@@ -0,0 +1,50 @@
+package cadence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewScheduleActivityTaskAttributesTranslatesRetryPolicy(t *testing.T) {
+	params := executeActivityParameters{
+		ActivityType: ActivityType{Name: "sampleActivity"},
+		TaskListName: "sampleTaskList",
+		RetryPolicy: &RetryPolicy{
+			InitialInterval:          time.Second,
+			BackoffCoefficient:       2.0,
+			MaximumInterval:          time.Minute,
+			MaximumAttempts:          5,
+			ExpirationInterval:       time.Hour,
+			NonRetryableErrorReasons: []string{"bad-input"},
+		},
+	}
+
+	attrs := newScheduleActivityTaskAttributes(params)
+
+	if attrs.RetryPolicy == nil {
+		t.Fatal("expected RetryPolicy to be translated, got nil")
+	}
+	if got := *attrs.RetryPolicy.InitialIntervalInSeconds; got != 1 {
+		t.Errorf("InitialIntervalInSeconds = %d, want 1", got)
+	}
+	if got := *attrs.RetryPolicy.BackoffCoefficient; got != 2.0 {
+		t.Errorf("BackoffCoefficient = %v, want 2.0", got)
+	}
+	if got := *attrs.RetryPolicy.MaximumAttempts; got != 5 {
+		t.Errorf("MaximumAttempts = %d, want 5", got)
+	}
+	if len(attrs.RetryPolicy.NonRetryableErrorReasons) != 1 || attrs.RetryPolicy.NonRetryableErrorReasons[0] != "bad-input" {
+		t.Errorf("NonRetryableErrorReasons = %v, want [bad-input]", attrs.RetryPolicy.NonRetryableErrorReasons)
+	}
+}
+
+func TestNewScheduleActivityTaskAttributesWithoutRetryPolicy(t *testing.T) {
+	attrs := newScheduleActivityTaskAttributes(executeActivityParameters{
+		ActivityType: ActivityType{Name: "sampleActivity"},
+		TaskListName: "sampleTaskList",
+	})
+
+	if attrs.RetryPolicy != nil {
+		t.Errorf("expected RetryPolicy to stay nil when none is configured, got %+v", attrs.RetryPolicy)
+	}
+}
@@ -0,0 +1,19 @@
+package cadence
+
+type (
+	// ActivityTaskCanceledError is returned by ServiceInvoker.Heartbeat when the activity has been cancelled,
+	// either explicitly through RequestCancelActivity or because its workflow has completed.
+	ActivityTaskCanceledError struct{}
+
+	// EntityNotExistsError is returned by ServiceInvoker.Heartbeat when the workflow or activity the heartbeat
+	// is for is no longer known to the service, e.g. because the workflow already completed.
+	EntityNotExistsError struct{}
+)
+
+func (e *ActivityTaskCanceledError) Error() string {
+	return "activity task canceled"
+}
+
+func (e *EntityNotExistsError) Error() string {
+	return "entity not exists"
+}
@@ -0,0 +1,93 @@
+package cadence
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type (
+	// Future represents the result of an asynchronous computation, such as ExecuteLocalActivity.
+	Future interface {
+		// Get blocks until the future is ready and then stores its value into valuePtr (if non-nil) or
+		// returns its error. Get also returns early with ctx.Err() if ctx is done before the future is ready.
+		Get(ctx Context, valuePtr interface{}) error
+		// IsReady returns true if the value or error of the future is ready.
+		IsReady() bool
+	}
+
+	// Settable is used to set the value or error of the Future it was returned alongside by NewFuture.
+	Settable interface {
+		Set(value interface{}, err error)
+	}
+
+	futureImpl struct {
+		mu            sync.Mutex
+		ready         bool
+		value         interface{}
+		err           error
+		ch            chan struct{}
+		dataConverter DataConverter
+	}
+)
+
+// NewFuture creates a new Future/Settable pair. The Future becomes ready once Settable.Set is called. Callers
+// that Set a raw, data-converter-encoded []byte (as ExecuteLocalActivity does) get it decoded automatically on
+// Get; anything else is assigned to valuePtr as-is.
+func NewFuture(ctx Context) (Future, Settable) {
+	f := &futureImpl{ch: make(chan struct{}), dataConverter: getDataConverterFromContext(ctx)}
+	return f, f
+}
+
+func (f *futureImpl) Set(value interface{}, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.ready {
+		return
+	}
+	f.value, f.err, f.ready = value, err, true
+	close(f.ch)
+}
+
+func (f *futureImpl) IsReady() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ready
+}
+
+func (f *futureImpl) Get(ctx Context, valuePtr interface{}) error {
+	select {
+	case <-f.ch:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	f.mu.Lock()
+	value, err := f.value, f.err
+	f.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if valuePtr == nil || value == nil {
+		return nil
+	}
+	if data, ok := value.([]byte); ok {
+		return f.dataConverter.FromData(data, valuePtr)
+	}
+	return assignFutureValue(value, valuePtr)
+}
+
+func assignFutureValue(value interface{}, valuePtr interface{}) error {
+	rv := reflect.ValueOf(valuePtr)
+	if rv.Kind() != reflect.Ptr {
+		return fmt.Errorf("valuePtr parameter %T is not a pointer", valuePtr)
+	}
+	elem := rv.Elem()
+	valueRV := reflect.ValueOf(value)
+	if !valueRV.Type().AssignableTo(elem.Type()) {
+		return fmt.Errorf("cannot assign future value of type %v to type %v", valueRV.Type(), elem.Type())
+	}
+	elem.Set(valueRV)
+	return nil
+}
@@ -0,0 +1,34 @@
+package cadence
+
+import "context"
+
+type (
+	// ActivityInboundInterceptor is invoked for every activity execution and heartbeat. An implementation
+	// wraps the next interceptor in the chain (or the activity's own Execute/Heartbeat once the chain is
+	// exhausted), and can inspect or mutate inputs and outputs around that call. GetActivityInfo and
+	// GetActivityLogger remain available on ctx throughout the chain, so middleware can use them without any
+	// special plumbing.
+	ActivityInboundInterceptor interface {
+		ExecuteActivity(ctx context.Context, input []byte) ([]byte, error)
+		Heartbeat(ctx context.Context, details []byte) error
+	}
+
+	// ActivityInterceptor constructs an ActivityInboundInterceptor that wraps the next interceptor in the
+	// chain. ExecuteActivityTask composes a chain of these (see newInterceptedActivity) from
+	// WorkerOptions.ActivityInterceptors around each activity's own Execute/Heartbeat so cross-cutting behavior
+	// - tracing, metrics, panic recovery, auth - can be applied to every activity the worker runs, without
+	// changing activity code.
+	ActivityInterceptor interface {
+		InterceptActivity(next ActivityInboundInterceptor) ActivityInboundInterceptor
+	}
+)
+
+// newInterceptedActivity composes interceptors around base so that interceptors[0] sees the call first and
+// interceptors[len-1] sits closest to base. An empty or nil interceptors returns base unchanged.
+func newInterceptedActivity(base ActivityInboundInterceptor, interceptors []ActivityInterceptor) ActivityInboundInterceptor {
+	chained := base
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		chained = interceptors[i].InterceptActivity(chained)
+	}
+	return chained
+}
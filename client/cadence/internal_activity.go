@@ -24,6 +24,16 @@ type (
 		activityID string
 	}
 
+	// resultHandler is invoked with the outcome of an activity scheduled through asyncActivityClient.ExecuteActivity.
+	resultHandler func(result []byte, err error)
+
+	// activityExecutorInterceptor adapts an activity implementation to ActivityInboundInterceptor so it can
+	// sit at the base of a worker's interceptor chain.
+	activityExecutorInterceptor struct {
+		activity activity
+		env      *activityEnvironment
+	}
+
 	// executeActivityParameters configuration parameters for scheduling an activity
 	executeActivityParameters struct {
 		ActivityID                    *string // Users can choose IDs but our framework makes it optional to decrease the crust.
@@ -35,6 +45,8 @@ type (
 		StartToCloseTimeoutSeconds    int32
 		HeartbeatTimeoutSeconds       int32
 		WaitForCancellation           bool
+		RetryPolicy                   *RetryPolicy
+		Header                        map[string][]byte
 	}
 
 	// asyncActivityClient for requesting activity execution
@@ -51,12 +63,18 @@ type (
 	}
 
 	activityEnvironment struct {
-		taskToken         []byte
-		workflowExecution WorkflowExecution
-		activityID        string
-		activityType      ActivityType
-		serviceInvoker    ServiceInvoker
-		logger            *zap.Logger
+		taskToken               []byte
+		workflowExecution       WorkflowExecution
+		activityID              string
+		activityType            ActivityType
+		serviceInvoker          ServiceInvoker
+		logger                  *zap.Logger
+		attempt                 int32
+		dataConverter           DataConverter
+		heartbeatTimeoutSeconds int32
+		cancelHandler           context.CancelFunc
+		workerStopChannel       <-chan struct{}
+		header                  map[string][]byte
 	}
 
 	// activityOptions stores all activity-specific parameters that will
@@ -69,11 +87,35 @@ type (
 		startToCloseTimeoutSeconds    *int32
 		heartbeatTimeoutSeconds       *int32
 		waitForCancellation           *bool
+		retryPolicy                   *RetryPolicy
+		dataConverter                 DataConverter
 	}
 )
 
 // Assert that structs do indeed implement the interfaces
 var _ ActivityOptions = (*activityOptions)(nil)
+var _ ActivityInboundInterceptor = (*activityExecutorInterceptor)(nil)
+
+func (a *activityExecutorInterceptor) ExecuteActivity(ctx context.Context, input []byte) ([]byte, error) {
+	return a.activity.Execute(ctx, input)
+}
+
+func (a *activityExecutorInterceptor) Heartbeat(ctx context.Context, details []byte) error {
+	return a.env.serviceInvoker.Heartbeat(details)
+}
+
+// executeActivityWithInterceptors runs act.Execute through the worker's configured interceptor chain,
+// falling back to calling act.Execute directly when no interceptors are configured.
+func executeActivityWithInterceptors(
+	ctx context.Context,
+	act activity,
+	env *activityEnvironment,
+	interceptors []ActivityInterceptor,
+	input []byte,
+) ([]byte, error) {
+	base := &activityExecutorInterceptor{activity: act, env: env}
+	return newInterceptedActivity(base, interceptors).ExecuteActivity(ctx, input)
+}
 
 const activityEnvContextKey = "activityEnv"
 const activityOptionsContextKey = "activityOptions"
@@ -109,9 +151,30 @@ func getValidatedActivityOptions(ctx Context) (*executeActivityParameters, error
 	if p.StartToCloseTimeoutSeconds <= 0 {
 		return nil, errors.New("missing or negative StartToCloseTimeoutSeconds")
 	}
+	if err := validateRetryPolicy(p.RetryPolicy); err != nil {
+		return nil, err
+	}
 	return p, nil
 }
 
+// validateRetryPolicy is shared by activity and local activity option validation. A nil policy is valid: it
+// means the activity is not retried.
+func validateRetryPolicy(rp *RetryPolicy) error {
+	if rp == nil {
+		return nil
+	}
+	if rp.InitialInterval <= 0 {
+		return errors.New("missing or negative RetryPolicy.InitialInterval")
+	}
+	if rp.MaximumAttempts <= 0 {
+		return errors.New("missing or negative RetryPolicy.MaximumAttempts")
+	}
+	if rp.BackoffCoefficient < 1.0 {
+		return errors.New("RetryPolicy.BackoffCoefficient must be 1.0 or larger")
+	}
+	return nil
+}
+
 func validateFunctionArgs(f interface{}, args []interface{}, isWorkflow bool) error {
 	fType := reflect.TypeOf(f)
 	if fType.Kind() != reflect.Func {
@@ -151,7 +214,7 @@ func validateFunctionArgs(f interface{}, args []interface{}, isWorkflow bool) er
 	return nil
 }
 
-func validateFunctionResults(f interface{}, result interface{}) ([]byte, error) {
+func validateFunctionResults(f interface{}, result interface{}, dataConverter DataConverter) ([]byte, error) {
 	fType := reflect.TypeOf(f)
 	switch fType.Kind() {
 	case reflect.String:
@@ -171,14 +234,14 @@ func validateFunctionResults(f interface{}, result interface{}) ([]byte, error)
 		return nil, nil
 	}
 
-	data, err := getHostEnvironment().encodeArg(result)
+	data, err := dataConverter.ToData(result)
 	if err != nil {
 		return nil, err
 	}
 	return data, nil
 }
 
-func getValidatedActivityFunction(f interface{}, args []interface{}) (*ActivityType, []byte, error) {
+func getValidatedActivityFunction(f interface{}, args []interface{}, dataConverter DataConverter) (*ActivityType, []byte, error) {
 	fnName := ""
 	fType := reflect.TypeOf(f)
 	switch fType.Kind() {
@@ -196,7 +259,7 @@ func getValidatedActivityFunction(f interface{}, args []interface{}) (*ActivityT
 			"Invalid type 'f' parameter provided, it can be either activity function or name of the activity: %v", f)
 	}
 
-	input, err := getHostEnvironment().encodeArgs(args)
+	input, err := dataConverter.ToData(args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -208,7 +271,18 @@ func isActivityContext(inType reflect.Type) bool {
 	return inType.Implements(contextElem)
 }
 
-func validateFunctionAndGetResults(f interface{}, values []reflect.Value) ([]byte, error) {
+// leadingContextArg returns a one-element reflect.Value slice holding ctx, and the index the function's
+// remaining parameters start at, if fnType's first parameter accepts a context.Context. Otherwise it returns
+// a nil slice and index 0. decodeActivityInput and buildLocalActivityArgValues both call this to decide
+// whether to prepend ctx before handling their own, differently-shaped remaining arguments.
+func leadingContextArg(fnType reflect.Type, ctx context.Context) ([]reflect.Value, int) {
+	if fnType.NumIn() > 0 && isActivityContext(fnType.In(0)) {
+		return []reflect.Value{reflect.ValueOf(ctx)}, 1
+	}
+	return nil, 0
+}
+
+func validateFunctionAndGetResults(f interface{}, values []reflect.Value, dataConverter DataConverter) ([]byte, error) {
 	fnName := getFunctionName(f)
 	resultSize := len(values)
 
@@ -224,7 +298,7 @@ func validateFunctionAndGetResults(f interface{}, values []reflect.Value) ([]byt
 	// Parse result
 	if resultSize > 1 {
 		r := values[0].Interface()
-		result, err = getHostEnvironment().encodeArg(r)
+		result, err = dataConverter.ToData(r)
 		if err != nil {
 			return nil, err
 		}
@@ -241,10 +315,12 @@ func validateFunctionAndGetResults(f interface{}, values []reflect.Value) ([]byt
 			"Failed to parse error result as it is not of error interface: %v",
 			errValue)
 	}
+	// errInterface may be ErrResultPending, in which case the caller must not report completion to the
+	// service: the activity stays in the Started state until Client.CompleteActivity is called out-of-band.
 	return result, errInterface
 }
 
-func deSerializeFnResultFromFnType(fnType reflect.Type, result []byte, to interface{}) error {
+func deSerializeFnResultFromFnType(fnType reflect.Type, result []byte, to interface{}, dataConverter DataConverter) error {
 	if fnType.Kind() != reflect.Func {
 		return fmt.Errorf("expecting only function type but got type: %v", fnType)
 	}
@@ -256,7 +332,7 @@ func deSerializeFnResultFromFnType(fnType reflect.Type, result []byte, to interf
 		if result == nil {
 			return nil
 		}
-		err := getHostEnvironment().decodeArg(result, to)
+		err := dataConverter.FromData(result, to)
 		if err != nil {
 			return err
 		}
@@ -264,24 +340,24 @@ func deSerializeFnResultFromFnType(fnType reflect.Type, result []byte, to interf
 	return nil
 }
 
-func deSerializeFunctionResult(f interface{}, result []byte, to interface{}) error {
+func deSerializeFunctionResult(f interface{}, result []byte, to interface{}, dataConverter DataConverter) error {
 	fType := reflect.TypeOf(f)
 
 	switch fType.Kind() {
 	case reflect.Func:
 		// We already validated that it either have (result, error) (or) just error.
-		return deSerializeFnResultFromFnType(fType, result, to)
+		return deSerializeFnResultFromFnType(fType, result, to, dataConverter)
 
 	case reflect.String:
 		// If we know about this function through registration then we will try to return corresponding result type.
 		fnName := reflect.ValueOf(f).String()
 		if fnRegistered, ok := getHostEnvironment().getActivityFn(fnName); ok {
-			return deSerializeFnResultFromFnType(reflect.TypeOf(fnRegistered), result, to)
+			return deSerializeFnResultFromFnType(reflect.TypeOf(fnRegistered), result, to, dataConverter)
 		}
 	}
 
 	// For everything we return result.
-	return getHostEnvironment().decodeArg(result, to)
+	return dataConverter.FromData(result, to)
 }
 
 func setActivityParametersIfNotExist(ctx Context) Context {
@@ -327,6 +403,18 @@ func (ab *activityOptions) WithWaitForCancellation(wait bool) ActivityOptions {
 	return ab
 }
 
+// WithRetryPolicy sets the retry policy for this Context.
+func (ab *activityOptions) WithRetryPolicy(retryPolicy *RetryPolicy) ActivityOptions {
+	ab.retryPolicy = retryPolicy
+	return ab
+}
+
+// WithDataConverter sets the data converter for this Context.
+func (ab *activityOptions) WithDataConverter(dataConverter DataConverter) ActivityOptions {
+	ab.dataConverter = dataConverter
+	return ab
+}
+
 // WithActivityID sets the activity task list ID for this Context.
 // NOTE: We don't expose configuring activity ID to the user, This is something will be done in future
 // so they have end to end scenario of how to use this ID to complete and fail an activity(business use case).
@@ -0,0 +1,54 @@
+package cadence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *RetryPolicy
+		wantErr bool
+	}{
+		{name: "nil policy is valid", policy: nil, wantErr: false},
+		{
+			name: "valid policy",
+			policy: &RetryPolicy{
+				InitialInterval:    time.Second,
+				BackoffCoefficient: 2.0,
+				MaximumInterval:    time.Minute,
+				MaximumAttempts:    5,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "missing InitialInterval",
+			policy:  &RetryPolicy{BackoffCoefficient: 2.0, MaximumAttempts: 5},
+			wantErr: true,
+		},
+		{
+			name:    "missing MaximumAttempts",
+			policy:  &RetryPolicy{InitialInterval: time.Second, BackoffCoefficient: 2.0},
+			wantErr: true,
+		},
+		{
+			name: "BackoffCoefficient below 1.0",
+			policy: &RetryPolicy{
+				InitialInterval:    time.Second,
+				BackoffCoefficient: 0.5,
+				MaximumAttempts:    5,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRetryPolicy(tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateRetryPolicy(%+v) error = %v, wantErr %v", tt.policy, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package cadence
+
+// All code in this file is private to the package.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/uber-go/cadence-client/common"
+)
+
+type (
+	// executeLocalActivityParams configuration parameters for executing a local activity
+	executeLocalActivityParams struct {
+		ScheduleToCloseTimeoutSeconds *int32
+		RetryPolicy                   *RetryPolicy
+		TaskListName                  *string
+	}
+
+	// localActivityOptions stores all local-activity-specific parameters that will
+	// be stored inside of a context.
+	localActivityOptions struct {
+		scheduleToCloseTimeoutSeconds *int32
+		retryPolicy                   *RetryPolicy
+		taskListName                  *string
+	}
+
+	// localActivityMarkerData is what gets recorded into the workflow history marker event so that replay can
+	// reconstruct the local activity's result without re-executing it.
+	localActivityMarkerData struct {
+		ActivityID   string
+		ActivityType string
+		ReplayTime   time.Time
+		Result       []byte
+		ErrReason    string
+	}
+)
+
+// Assert that structs do indeed implement the interfaces
+var _ LocalActivityOptions = (*localActivityOptions)(nil)
+
+const localActivityOptionsContextKey = "localActivityOptions"
+const localActivityMarkerName = "LocalActivity"
+
+// localActivityBudgetExceeded is returned when a local activity cannot complete within the remaining
+// decision task timeout and ExecuteLocalActivity was configured to fail rather than fall back to a
+// regularly scheduled activity.
+var localActivityBudgetExceeded = errors.New("local activity did not complete within the decision task budget")
+
+func getLocalActivityOptions(ctx Context) *executeLocalActivityParams {
+	eap := ctx.Value(localActivityOptionsContextKey)
+	if eap == nil {
+		return nil
+	}
+	return eap.(*executeLocalActivityParams)
+}
+
+func getValidatedLocalActivityOptions(ctx Context) (*executeLocalActivityParams, error) {
+	p := getLocalActivityOptions(ctx)
+	if p == nil {
+		return nil, errActivityParamsBadRequest
+	}
+	if p.ScheduleToCloseTimeoutSeconds == nil || *p.ScheduleToCloseTimeoutSeconds <= 0 {
+		return nil, errors.New("missing or negative ScheduleToCloseTimeoutSeconds")
+	}
+	if p.TaskListName == nil || *p.TaskListName == "" {
+		return nil, errors.New("missing TaskListName")
+	}
+	if err := validateRetryPolicy(p.RetryPolicy); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WithScheduleToCloseTimeout sets timeout for this Context.
+func (lo *localActivityOptions) WithScheduleToCloseTimeout(d time.Duration) LocalActivityOptions {
+	lo.scheduleToCloseTimeoutSeconds = common.Int32Ptr(int32(d.Seconds()))
+	return lo
+}
+
+// WithRetryPolicy sets the retry policy for this Context.
+func (lo *localActivityOptions) WithRetryPolicy(retryPolicy *RetryPolicy) LocalActivityOptions {
+	lo.retryPolicy = retryPolicy
+	return lo
+}
+
+// WithTaskList sets the task list for this Context.
+func (lo *localActivityOptions) WithTaskList(name string) LocalActivityOptions {
+	lo.taskListName = common.StringPtr(name)
+	return lo
+}
+
+// localActivityMinimumBudget is the smallest remaining decision task budget executeLocalActivity will still
+// attempt a local run with; below this it falls through to a regularly scheduled activity instead, so the
+// decision task itself never has a chance to time out waiting on the local activity.
+const localActivityMinimumBudget = 100 * time.Millisecond
+
+// executeLocalActivity runs fn(args...) in-process, honoring params.RetryPolicy and the current decision
+// task's remaining time budget. On success or permanent failure it records a single marker so that replaying
+// the workflow reconstructs the same outcome instead of re-running fn. If this is a replay and the marker is
+// already in history, fn is not invoked at all. If the decision task does not have enough budget left to
+// attempt fn, it returns localActivityBudgetExceeded so the caller can fall through to env.ExecuteActivity.
+func executeLocalActivity(
+	ctx Context,
+	env WorkflowEnvironment,
+	params *executeLocalActivityParams,
+	fn interface{},
+	args []interface{},
+) (*ActivityType, []byte, error) {
+	activityType := &ActivityType{Name: getFunctionName(fn)}
+	markerID := fmt.Sprintf("%s_%d", localActivityMarkerName, env.GenerateSequenceID())
+	dataConverter := env.GetDataConverter()
+
+	if data, ok := env.GetRecordedMarker(markerID); ok {
+		var recorded localActivityMarkerData
+		if err := dataConverter.FromData(data, &recorded); err != nil {
+			return activityType, nil, err
+		}
+		if recorded.ErrReason != "" {
+			return activityType, nil, errors.New(recorded.ErrReason)
+		}
+		return activityType, recorded.Result, nil
+	}
+
+	if deadline := env.DecisionTaskDeadline(); !deadline.IsZero() && deadline.Sub(env.Now()) < localActivityMinimumBudget {
+		return activityType, nil, localActivityBudgetExceeded
+	}
+
+	result, fnErr := runLocalActivityWithRetry(ctx, dataConverter, params, fn, args)
+
+	marker := localActivityMarkerData{
+		ActivityID:   markerID,
+		ActivityType: activityType.Name,
+		ReplayTime:   env.Now(),
+		Result:       result,
+	}
+	if fnErr != nil {
+		marker.ErrReason = fnErr.Error()
+	}
+	data, err := dataConverter.ToData(marker)
+	if err != nil {
+		return activityType, nil, err
+	}
+	if err := env.RecordMarker(markerID, data); err != nil {
+		return activityType, nil, err
+	}
+
+	return activityType, result, fnErr
+}
+
+// runLocalActivityWithRetry invokes fn(args...) and, if it fails and params.RetryPolicy is set, retries with
+// backoff until the function succeeds, the error is listed in NonRetryableErrorReasons, MaximumAttempts is
+// reached, ExpirationInterval elapses, or ctx is cancelled. activityCtx carries params.ScheduleToCloseTimeoutSeconds
+// as its deadline, same as a regular activity's ScheduleToCloseTimeout; fn is expected to honor ctx.Done() the
+// same way it would if invoked as a scheduled activity.
+func runLocalActivityWithRetry(
+	ctx Context,
+	dataConverter DataConverter,
+	params *executeLocalActivityParams,
+	fn interface{},
+	args []interface{},
+) ([]byte, error) {
+	fnValue := reflect.ValueOf(fn)
+
+	scheduleToClose := time.Duration(*params.ScheduleToCloseTimeoutSeconds) * time.Second
+	activityCtx, cancel := context.WithTimeout(context.Background(), scheduleToClose)
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-activityCtx.Done():
+		}
+	}()
+	argValues := buildLocalActivityArgValues(fnValue.Type(), activityCtx, args)
+
+	var attempt int32
+	backoff := time.Duration(0)
+	if params.RetryPolicy != nil {
+		backoff = params.RetryPolicy.InitialInterval
+	}
+	deadline := time.Time{}
+	if params.RetryPolicy != nil && params.RetryPolicy.ExpirationInterval > 0 {
+		deadline = time.Now().Add(params.RetryPolicy.ExpirationInterval)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		attempt++
+		data, fnErr := validateFunctionAndGetResults(fn, fnValue.Call(argValues), dataConverter)
+		if fnErr == nil {
+			return data, nil
+		}
+
+		rp := params.RetryPolicy
+		if rp == nil {
+			return data, fnErr
+		}
+		if isNonRetryableLocalActivityError(fnErr, rp.NonRetryableErrorReasons) {
+			return data, fnErr
+		}
+		if rp.MaximumAttempts > 0 && attempt >= rp.MaximumAttempts {
+			return data, fnErr
+		}
+		if !deadline.IsZero() && !time.Now().Before(deadline) {
+			return data, fnErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff = time.Duration(float64(backoff) * rp.BackoffCoefficient)
+		if rp.MaximumInterval > 0 && backoff > rp.MaximumInterval {
+			backoff = rp.MaximumInterval
+		}
+	}
+}
+
+// buildLocalActivityArgValues mirrors decodeActivityInput's handling of a leading context.Context parameter
+// (via the shared leadingContextArg): a local activity fn written in the conventional
+// func(ctx context.Context, args...) shape (see RegisterActivity) gets activityCtx prepended; one that takes
+// no context is called with args alone.
+func buildLocalActivityArgValues(fnType reflect.Type, activityCtx context.Context, args []interface{}) []reflect.Value {
+	prefix, _ := leadingContextArg(fnType, activityCtx)
+	argValues := make([]reflect.Value, 0, len(prefix)+len(args))
+	argValues = append(argValues, prefix...)
+	for _, a := range args {
+		argValues = append(argValues, reflect.ValueOf(a))
+	}
+	return argValues
+}
+
+func isNonRetryableLocalActivityError(err error, nonRetryableReasons []string) bool {
+	for _, reason := range nonRetryableReasons {
+		if reason == err.Error() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package cadence
+
+import (
+	"time"
+)
+
+type (
+	// LocalActivityOptions stores local activity specific parameters that will
+	// be stored inside of a context.
+	LocalActivityOptions interface {
+		WithScheduleToCloseTimeout(d time.Duration) LocalActivityOptions
+		WithRetryPolicy(retryPolicy *RetryPolicy) LocalActivityOptions
+		// WithTaskList sets the task list a fallback, regularly scheduled activity is sent to if the local
+		// activity doesn't fit in the current decision task's remaining time budget. Required, since that
+		// fallback is always possible.
+		WithTaskList(name string) LocalActivityOptions
+	}
+)
+
+// NewLocalActivityOptions returns an instance of local activity options that can be used to specify
+// options for a local activity through context.
+//			ctx1 := WithLocalActivityOptions(ctx, NewLocalActivityOptions().
+//					WithScheduleToCloseTimeout(time.Second).
+//					WithTaskList("sampleTaskList"))
+func NewLocalActivityOptions() LocalActivityOptions {
+	return &localActivityOptions{}
+}
+
+// WithLocalActivityOptions adds all local activity options to the context.
+func WithLocalActivityOptions(ctx Context, options LocalActivityOptions) Context {
+	opts := options.(*localActivityOptions)
+	return WithValue(ctx, localActivityOptionsContextKey, &executeLocalActivityParams{
+		ScheduleToCloseTimeoutSeconds: opts.scheduleToCloseTimeoutSeconds,
+		RetryPolicy:                   opts.retryPolicy,
+		TaskListName:                  opts.taskListName,
+	})
+}
+
+// ExecuteLocalActivity requests execution of a local activity in-process. The local activity runs on the
+// decision worker that is currently processing the workflow task, without going through the task list and
+// without creating an ActivityTask on the server. This is suitable for short-lived activities that don't need
+// to be distributed, retried independently across hosts, or rate limited by a task list.
+//
+// Use WithLocalActivityOptions to configure the ScheduleToCloseTimeout, task list, and an optional RetryPolicy
+// before calling ExecuteLocalActivity. A single marker event recording the input and result is written to the
+// workflow history so replay stays deterministic. ExecuteLocalActivity returns a Future that becomes ready
+// once the local activity (and its retries, if any) complete or the context is cancelled.
+//
+// If the local activity does not finish within its share of the current decision task's time budget, it is
+// transparently converted into a regular scheduled activity so the decision task itself never times out; this
+// conversion is invisible to callers other than the added latency of going through the task list.
+func ExecuteLocalActivity(ctx Context, localActivityFn interface{}, args ...interface{}) Future {
+	future, settable := NewFuture(ctx)
+
+	p, err := getValidatedLocalActivityOptions(ctx)
+	if err != nil {
+		settable.Set(nil, err)
+		return future
+	}
+	if err := validateFunctionArgs(localActivityFn, args, false); err != nil {
+		settable.Set(nil, err)
+		return future
+	}
+
+	env := getWorkflowEnvironment(ctx)
+	activityType, result, fnErr := executeLocalActivity(ctx, env, p, localActivityFn, args)
+
+	if fnErr == localActivityBudgetExceeded {
+		fallbackToScheduledActivity(ctx, env, p, activityType, localActivityFn, args, settable)
+		return future
+	}
+
+	settable.Set(result, fnErr)
+	return future
+}
+
+// fallbackToScheduledActivity is invoked when a local activity can't fit in the current decision task's
+// remaining budget. It schedules a regular, server-tracked activity with the same function, arguments and
+// retry policy so that, other than added latency, the fallback is invisible to the caller. Since local
+// activities only configure a single ScheduleToCloseTimeout, that same duration is used for the fallback's
+// ScheduleToStartTimeout and StartToCloseTimeout.
+func fallbackToScheduledActivity(
+	ctx Context,
+	env WorkflowEnvironment,
+	p *executeLocalActivityParams,
+	activityType *ActivityType,
+	localActivityFn interface{},
+	args []interface{},
+	settable Settable,
+) {
+	_, input, err := getValidatedActivityFunction(localActivityFn, args, env.GetDataConverter())
+	if err != nil {
+		settable.Set(nil, err)
+		return
+	}
+	params := executeActivityParameters{
+		ActivityType:                  *activityType,
+		TaskListName:                  *p.TaskListName,
+		Input:                         input,
+		ScheduleToCloseTimeoutSeconds: *p.ScheduleToCloseTimeoutSeconds,
+		ScheduleToStartTimeoutSeconds: *p.ScheduleToCloseTimeoutSeconds,
+		StartToCloseTimeoutSeconds:    *p.ScheduleToCloseTimeoutSeconds,
+		RetryPolicy:                   p.RetryPolicy,
+	}
+	env.ExecuteActivity(params, func(result []byte, err error) {
+		settable.Set(result, err)
+	})
+}
@@ -0,0 +1,53 @@
+package cadence
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func sampleLocalActivityWithContext(ctx context.Context, a, b int) (int, error) {
+	return a + b, nil
+}
+
+func sampleLocalActivityWithoutContext(a, b int) (int, error) {
+	return a + b, nil
+}
+
+func TestBuildLocalActivityArgValuesPrependsContext(t *testing.T) {
+	fnType := reflect.TypeOf(sampleLocalActivityWithContext)
+	activityCtx := context.Background()
+
+	argValues := buildLocalActivityArgValues(fnType, activityCtx, []interface{}{2, 3})
+
+	if len(argValues) != 3 {
+		t.Fatalf("expected 3 reflect values (ctx, a, b), got %d", len(argValues))
+	}
+	if !argValues[0].Type().Implements(reflect.TypeOf((*context.Context)(nil)).Elem()) {
+		t.Fatalf("expected first value to be a context.Context, got %v", argValues[0].Type())
+	}
+	if argValues[1].Interface() != 2 || argValues[2].Interface() != 3 {
+		t.Fatalf("expected args (2, 3), got (%v, %v)", argValues[1].Interface(), argValues[2].Interface())
+	}
+
+	// Calling through reflection must not panic with "too few input arguments".
+	results := reflect.ValueOf(sampleLocalActivityWithContext).Call(argValues)
+	if sum := results[0].Interface().(int); sum != 5 {
+		t.Errorf("sum = %d, want 5", sum)
+	}
+}
+
+func TestBuildLocalActivityArgValuesWithoutContext(t *testing.T) {
+	fnType := reflect.TypeOf(sampleLocalActivityWithoutContext)
+
+	argValues := buildLocalActivityArgValues(fnType, context.Background(), []interface{}{2, 3})
+
+	if len(argValues) != 2 {
+		t.Fatalf("expected 2 reflect values (a, b), got %d", len(argValues))
+	}
+
+	results := reflect.ValueOf(sampleLocalActivityWithoutContext).Call(argValues)
+	if sum := results[0].Interface().(int); sum != 5 {
+		t.Errorf("sum = %d, want 5", sum)
+	}
+}
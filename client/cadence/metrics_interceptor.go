@@ -0,0 +1,51 @@
+package cadence
+
+import (
+	"context"
+
+	"github.com/uber-go/tally"
+)
+
+type (
+	metricsInterceptorFactory struct {
+		scope tally.Scope
+	}
+
+	metricsActivityInterceptor struct {
+		scope tally.Scope
+		next  ActivityInboundInterceptor
+	}
+)
+
+// NewMetricsActivityInterceptor returns an ActivityInterceptor that emits activity latency and error-count
+// metrics to scope, tagged with the activity type name. Include it in WorkerOptions.ActivityInterceptors to
+// have ExecuteActivityTask apply it to every activity the worker runs.
+func NewMetricsActivityInterceptor(scope tally.Scope) ActivityInterceptor {
+	return &metricsInterceptorFactory{scope: scope}
+}
+
+func (f *metricsInterceptorFactory) InterceptActivity(next ActivityInboundInterceptor) ActivityInboundInterceptor {
+	return &metricsActivityInterceptor{scope: f.scope, next: next}
+}
+
+func (m *metricsActivityInterceptor) ExecuteActivity(ctx context.Context, input []byte) ([]byte, error) {
+	info := GetActivityInfo(ctx)
+	scope := m.scope.Tagged(map[string]string{"ActivityType": info.ActivityType.Name})
+
+	sw := scope.Timer("activity.latency").Start()
+	defer sw.Stop()
+
+	result, err := m.next.ExecuteActivity(ctx, input)
+	if err != nil {
+		scope.Counter("activity.error").Inc(1)
+	} else {
+		scope.Counter("activity.success").Inc(1)
+	}
+	return result, err
+}
+
+func (m *metricsActivityInterceptor) Heartbeat(ctx context.Context, details []byte) error {
+	info := GetActivityInfo(ctx)
+	m.scope.Tagged(map[string]string{"ActivityType": info.ActivityType.Name}).Counter("activity.heartbeat").Inc(1)
+	return m.next.Heartbeat(ctx, details)
+}
@@ -0,0 +1,93 @@
+package cadence
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/uber-go/cadence-client/.gen/go/shared"
+	"go.uber.org/zap"
+)
+
+type (
+	// reflectActivity adapts a registered Go function to the activity interface by decoding its input through
+	// reflection before calling it, and encoding its result the same way validateFunctionAndGetResults does for
+	// every other activity invocation path.
+	reflectActivity struct {
+		fn            interface{}
+		activityType  ActivityType
+		dataConverter DataConverter
+	}
+)
+
+var _ activity = (*reflectActivity)(nil)
+
+func (a *reflectActivity) ActivityType() ActivityType {
+	return a.activityType
+}
+
+func (a *reflectActivity) Execute(ctx context.Context, input []byte) ([]byte, error) {
+	fnValue := reflect.ValueOf(a.fn)
+	args, err := decodeActivityInput(ctx, fnValue.Type(), input, a.dataConverter)
+	if err != nil {
+		return nil, err
+	}
+	return validateFunctionAndGetResults(a.fn, fnValue.Call(args), a.dataConverter)
+}
+
+// decodeActivityInput builds the reflect.Value argument list fnType expects: ctx prepended if the function
+// takes a context.Context, followed by input decoded through dataConverter, one target per remaining
+// parameter.
+func decodeActivityInput(ctx context.Context, fnType reflect.Type, input []byte, dataConverter DataConverter) ([]reflect.Value, error) {
+	numIn := fnType.NumIn()
+	prefix, startIndex := leadingContextArg(fnType, ctx)
+	args := make([]reflect.Value, 0, numIn)
+	args = append(args, prefix...)
+	if startIndex == numIn {
+		return args, nil
+	}
+
+	targets := make([]reflect.Value, numIn-startIndex)
+	targetPtrs := make([]interface{}, len(targets))
+	for i := range targets {
+		targets[i] = reflect.New(fnType.In(startIndex + i))
+		targetPtrs[i] = targets[i].Interface()
+	}
+	if err := dataConverter.FromData(input, targetPtrs...); err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		args = append(args, target.Elem())
+	}
+	return args, nil
+}
+
+// ExecuteActivityTask is the entry point a worker's task poller calls once it has received an activity task
+// from the service: it resolves the registered activity by type name, decodes its input, and runs it through
+// the interceptor chain configured in options. workerStopChannel, if non-nil, is closed when the worker is
+// shutting down; an activity using WithAutoHeartbeat unwinds through ctx.Done() when that happens.
+func ExecuteActivityTask(
+	ctx context.Context,
+	task *shared.PollForActivityTaskResponse,
+	invoker ServiceInvoker,
+	logger *zap.Logger,
+	workerStopChannel <-chan struct{},
+	options WorkerOptions,
+) ([]byte, error) {
+	dataConverter := options.DataConverter
+	if dataConverter == nil {
+		dataConverter = getDefaultDataConverter()
+	}
+
+	ctx = WithActivityTask(ctx, task, invoker, logger, dataConverter, workerStopChannel)
+	env := getActivityEnv(ctx)
+
+	fnName := *task.ActivityType.Name
+	fn, ok := getHostEnvironment().getActivityFn(fnName)
+	if !ok {
+		return nil, fmt.Errorf("unable to find activityType=%v, did you forget to register it with RegisterActivity?", fnName)
+	}
+
+	act := &reflectActivity{fn: fn, activityType: env.activityType, dataConverter: dataConverter}
+	return executeActivityWithInterceptors(ctx, act, env, options.ActivityInterceptors, task.Input)
+}
@@ -0,0 +1,47 @@
+package cadence
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func sampleAddActivity(ctx context.Context, a, b int) (int, error) {
+	return a + b, nil
+}
+
+func sampleFailingActivity() error {
+	return errors.New("boom")
+}
+
+func TestReflectActivityExecuteDecodesArgsAndContext(t *testing.T) {
+	dataConverter := getDefaultDataConverter()
+	input, err := dataConverter.ToData(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	act := &reflectActivity{fn: sampleAddActivity, activityType: ActivityType{Name: "sampleAddActivity"}, dataConverter: dataConverter}
+	result, err := act.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sum int
+	if err := dataConverter.FromData(result, &sum); err != nil {
+		t.Fatal(err)
+	}
+	if sum != 5 {
+		t.Errorf("result = %d, want 5", sum)
+	}
+}
+
+func TestReflectActivityExecutePropagatesError(t *testing.T) {
+	dataConverter := getDefaultDataConverter()
+	act := &reflectActivity{fn: sampleFailingActivity, activityType: ActivityType{Name: "sampleFailingActivity"}, dataConverter: dataConverter}
+
+	_, err := act.Execute(context.Background(), nil)
+	if err == nil || err.Error() != "boom" {
+		t.Fatalf("expected error \"boom\", got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package cadence
+
+import (
+	"context"
+
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+type (
+	tracingInterceptorFactory struct {
+		tracer opentracing.Tracer
+	}
+
+	// tracingActivityInterceptor starts a span per activity execution, as a child of whatever span context is
+	// carried in the activity's header, if any.
+	tracingActivityInterceptor struct {
+		tracer opentracing.Tracer
+		next   ActivityInboundInterceptor
+	}
+
+	// byteMapCarrier adapts the []byte-valued header map activities are scheduled with to
+	// opentracing.TextMapReader so a propagated span context can be extracted from it.
+	byteMapCarrier map[string][]byte
+)
+
+func (c byteMapCarrier) ForeachKey(handler func(key, val string) error) error {
+	for k, v := range c {
+		if err := handler(k, string(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewTracingActivityInterceptor returns an ActivityInterceptor that starts an OpenTracing span, named after
+// the activity type, for each activity execution. If the activity's header carries a parent span context
+// (see GetActivityHeader), the new span is a child of it. Set WorkerOptions.ActivityInterceptors to include
+// this so ExecuteActivityTask traces every activity the worker runs.
+func NewTracingActivityInterceptor(tracer opentracing.Tracer) ActivityInterceptor {
+	return &tracingInterceptorFactory{tracer: tracer}
+}
+
+func (f *tracingInterceptorFactory) InterceptActivity(next ActivityInboundInterceptor) ActivityInboundInterceptor {
+	return &tracingActivityInterceptor{tracer: f.tracer, next: next}
+}
+
+func (t *tracingActivityInterceptor) ExecuteActivity(ctx context.Context, input []byte) ([]byte, error) {
+	info := GetActivityInfo(ctx)
+
+	var opts []opentracing.StartSpanOption
+	if header := GetActivityHeader(ctx); len(header) > 0 {
+		if parentCtx, err := t.tracer.Extract(opentracing.TextMap, byteMapCarrier(header)); err == nil {
+			opts = append(opts, opentracing.ChildOf(parentCtx))
+		}
+	}
+	span := t.tracer.StartSpan("RunActivity:"+info.ActivityType.Name, opts...)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	result, err := t.next.ExecuteActivity(ctx, input)
+	if err != nil {
+		span.SetTag("error", true)
+		span.LogKV("error.message", err.Error())
+	}
+	return result, err
+}
+
+func (t *tracingActivityInterceptor) Heartbeat(ctx context.Context, details []byte) error {
+	return t.next.Heartbeat(ctx, details)
+}
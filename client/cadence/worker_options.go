@@ -0,0 +1,11 @@
+package cadence
+
+// WorkerOptions configures the cross-cutting behavior a worker applies to every activity task it executes.
+type WorkerOptions struct {
+	// DataConverter serializes/deserializes activity input and result. Defaults to the JSON converter if nil.
+	DataConverter DataConverter
+
+	// ActivityInterceptors is the chain applied around every activity the worker runs, in the order given:
+	// ActivityInterceptors[0] sees the call first. See ExecuteActivityTask.
+	ActivityInterceptors []ActivityInterceptor
+}
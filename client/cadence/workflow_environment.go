@@ -0,0 +1,57 @@
+package cadence
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type (
+	// WorkflowEnvironment is implemented by the decision task handler and injected into the workflow Context.
+	// It exposes the primitives workflow-side features need in order to stay deterministic across replay:
+	// logical time, the current decision task's remaining budget, marker recording/lookup, and the ability to
+	// schedule a regular server-side activity.
+	WorkflowEnvironment interface {
+		// Now returns the workflow's current logical time. During replay this is the time recorded in
+		// history, not wall-clock time.
+		Now() time.Time
+		// DecisionTaskDeadline returns when the current decision task must be completed by. The zero value
+		// means no deadline is known.
+		DecisionTaskDeadline() time.Time
+		// IsReplaying reports whether the current decision is being replayed from history rather than
+		// executed live.
+		IsReplaying() bool
+		// GetRecordedMarker returns the data previously recorded under markerID, if this is a replay and the
+		// marker event is already present in history.
+		GetRecordedMarker(markerID string) (data []byte, ok bool)
+		// RecordMarker records a marker event under markerID so that a later replay's GetRecordedMarker
+		// returns data instead of re-running whatever produced it.
+		RecordMarker(markerID string, data []byte) error
+		// GenerateSequenceID returns the next value in a counter that advances identically on replay and on
+		// first execution, so callers can build marker/activity IDs that stay stable across replay.
+		GenerateSequenceID() int32
+		// ExecuteActivity schedules a regular, server-tracked activity and invokes callback with its result.
+		ExecuteActivity(parameters executeActivityParameters, callback resultHandler) *activityInfo
+		// GetDataConverter returns the DataConverter in effect for the current workflow.
+		GetDataConverter() DataConverter
+		// GetLogger returns a logger scoped to the current workflow.
+		GetLogger() *zap.Logger
+	}
+)
+
+const workflowEnvironmentContextKey = "workflowEnvironment"
+
+// WithWorkflowEnvironment adds env to ctx. The decision task handler calls this once per workflow task before
+// running workflow code; tests can call it directly to exercise workflow-side features like
+// ExecuteLocalActivity against a fake WorkflowEnvironment.
+func WithWorkflowEnvironment(ctx Context, env WorkflowEnvironment) Context {
+	return WithValue(ctx, workflowEnvironmentContextKey, env)
+}
+
+func getWorkflowEnvironment(ctx Context) WorkflowEnvironment {
+	env := ctx.Value(workflowEnvironmentContextKey)
+	if env == nil {
+		panic("getWorkflowEnvironment: Not a workflow context")
+	}
+	return env.(WorkflowEnvironment)
+}